@@ -0,0 +1,61 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+import "context"
+
+type contextKey struct{}
+
+// defaultLogger is returned by FromContext when ctx carries no logger of
+// its own.
+var defaultLogger ExtendedLogger = New()
+
+// NewContext returns a copy of ctx that carries l, retrievable with
+// FromContext.
+func NewContext(ctx context.Context, l ExtendedLogger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the ExtendedLogger attached to ctx via NewContext,
+// falling back to a package-default logger if none was attached. The
+// returned logger is scoped to ctx the same way (*DefaultLogger).WithContext
+// is, so it picks up OpenTelemetry trace correlation when built with the
+// "otel" build tag.
+func FromContext(ctx context.Context) ExtendedLogger {
+	l, ok := ctx.Value(contextKey{}).(ExtendedLogger)
+	if !ok {
+		l = defaultLogger
+	}
+	return injectTraceFields(ctx, l)
+}
+
+// Tracef logs at LevelTrace using the logger attached to ctx.
+func Tracef(ctx context.Context, format string, args ...interface{}) {
+	FromContext(ctx).Tracef(format, args...)
+}
+
+// Debugf logs at LevelDebug using the logger attached to ctx.
+func Debugf(ctx context.Context, format string, args ...interface{}) {
+	FromContext(ctx).Debugf(format, args...)
+}
+
+// Infof logs at LevelInfo using the logger attached to ctx.
+func Infof(ctx context.Context, format string, args ...interface{}) {
+	FromContext(ctx).Infof(format, args...)
+}
+
+// Warnf logs at LevelWarning using the logger attached to ctx.
+func Warnf(ctx context.Context, format string, args ...interface{}) {
+	FromContext(ctx).Warnf(format, args...)
+}
+
+// Errorf logs at LevelError using the logger attached to ctx.
+func Errorf(ctx context.Context, format string, args ...interface{}) {
+	FromContext(ctx).Errorf(format, args...)
+}
+
+// Criticalf logs at LevelCritical using the logger attached to ctx.
+func Criticalf(ctx context.Context, format string, args ...interface{}) {
+	FromContext(ctx).Criticalf(format, args...)
+}
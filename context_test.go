@@ -0,0 +1,59 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewContextFromContextRoundTrip(t *testing.T) {
+	buf := new(strings.Builder)
+	l := New(WithWriter(buf), WithFormatter(&TextFormatter{}))
+
+	ctx := NewContext(context.Background(), l)
+	got := FromContext(ctx)
+
+	got.Infof("from attached logger")
+	if !strings.Contains(buf.String(), "from attached logger") {
+		t.Errorf("expected FromContext to return the attached logger, got %q", buf.String())
+	}
+}
+
+func TestFromContextFallsBackToDefaultLogger(t *testing.T) {
+	got := FromContext(context.Background())
+	if got != defaultLogger {
+		t.Errorf("expected FromContext on a bare context to return defaultLogger, got %v", got)
+	}
+}
+
+func TestPackageLevelCtxHelpersWriteThroughAttachedLogger(t *testing.T) {
+	buf := new(strings.Builder)
+	l := New(WithWriter(buf), WithFormatter(&TextFormatter{}), WithInitialLevel(LevelTrace))
+	ctx := NewContext(context.Background(), l)
+
+	tests := []struct {
+		name string
+		call func()
+		want string
+	}{
+		{"Tracef", func() { Tracef(ctx, "trace %s", "msg") }, "trace msg"},
+		{"Debugf", func() { Debugf(ctx, "debug %s", "msg") }, "debug msg"},
+		{"Infof", func() { Infof(ctx, "info %s", "msg") }, "info msg"},
+		{"Warnf", func() { Warnf(ctx, "warn %s", "msg") }, "warn msg"},
+		{"Errorf", func() { Errorf(ctx, "error %s", "msg") }, "error msg"},
+		{"Criticalf", func() { Criticalf(ctx, "critical %s", "msg") }, "critical msg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.call()
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("%s: got %q, want substring %q", tt.name, buf.String(), tt.want)
+			}
+		})
+	}
+}
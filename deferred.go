@@ -0,0 +1,17 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+// DeferredLogger is implemented by loggers that can avoid the cost of
+// building a message when the corresponding level is not enabled, by
+// accepting a closure instead of a pre-formatted string. The closure is
+// only invoked if the level is actually going to be logged.
+type DeferredLogger interface {
+	TraceDeferred(fn func() string)
+	DebugDeferred(fn func() string)
+	InfoDeferred(fn func() string)
+	WarnDeferred(fn func() string)
+	ErrorDeferred(fn func() string)
+	CriticalDeferred(fn func() string)
+}
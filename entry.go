@@ -0,0 +1,16 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+import "time"
+
+// Entry is a single log record handed to a Formatter for rendering.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Actor   string
+	Event   string
+	Fields  map[string]interface{}
+}
@@ -0,0 +1,105 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+import (
+	"fmt"
+	"time"
+)
+
+// FieldType identifies the concrete type of value held by a Field, so
+// adapters can switch on it instead of doing a type assertion on Any.
+type FieldType int
+
+const (
+	// FieldTypeBool marks a Field holding a bool in Int (0 or 1).
+	FieldTypeBool FieldType = iota
+	// FieldTypeInt64 marks a Field holding an int64 in Int.
+	FieldTypeInt64
+	// FieldTypeUint64 marks a Field holding a uint64 in Int.
+	FieldTypeUint64
+	// FieldTypeFloat64 marks a Field holding a float64 in Float.
+	FieldTypeFloat64
+	// FieldTypeString marks a Field holding a string in Str.
+	FieldTypeString
+	// FieldTypeDuration marks a Field holding a time.Duration (nanoseconds) in Int.
+	FieldTypeDuration
+	// FieldTypeTime marks a Field holding a time.Time in Any.
+	FieldTypeTime
+	// FieldTypeError marks a Field holding an error in Any.
+	FieldTypeError
+	// FieldTypeStringer marks a Field holding a fmt.Stringer in Any.
+	FieldTypeStringer
+	// FieldTypeAny marks a Field holding an arbitrary value in Any.
+	FieldTypeAny
+)
+
+// Field is a single typed key/value pair attached to a structured log
+// entry. It avoids the boxing and map allocation of WithFields' plain
+// map[string]interface{} by carrying the value inline whenever possible.
+type Field struct {
+	Key   string
+	Type  FieldType
+	Int   int64
+	Float float64
+	Str   string
+	Any   interface{}
+}
+
+// Bool constructs a Field holding a bool.
+func Bool(key string, val bool) Field {
+	i := int64(0)
+	if val {
+		i = 1
+	}
+	return Field{Key: key, Type: FieldTypeBool, Int: i}
+}
+
+// Int64 constructs a Field holding an int64.
+func Int64(key string, val int64) Field {
+	return Field{Key: key, Type: FieldTypeInt64, Int: val}
+}
+
+// Uint64 constructs a Field holding a uint64.
+func Uint64(key string, val uint64) Field {
+	return Field{Key: key, Type: FieldTypeUint64, Int: int64(val)}
+}
+
+// Float64 constructs a Field holding a float64.
+func Float64(key string, val float64) Field {
+	return Field{Key: key, Type: FieldTypeFloat64, Float: val}
+}
+
+// String constructs a Field holding a string.
+func String(key, val string) Field {
+	return Field{Key: key, Type: FieldTypeString, Str: val}
+}
+
+// Duration constructs a Field holding a time.Duration.
+func Duration(key string, val time.Duration) Field {
+	return Field{Key: key, Type: FieldTypeDuration, Int: int64(val)}
+}
+
+// Time constructs a Field holding a time.Time.
+func Time(key string, val time.Time) Field {
+	return Field{Key: key, Type: FieldTypeTime, Any: val}
+}
+
+// Err constructs a Field holding an error under the key "error".
+func Err(err error) Field {
+	return Field{Key: "error", Type: FieldTypeError, Any: err}
+}
+
+// Stringer constructs a Field holding a fmt.Stringer. DefaultLogger calls
+// its String method only when the entry's level is enabled, before the
+// entry is handed to the formatter.
+func Stringer(key string, val fmt.Stringer) Field {
+	return Field{Key: key, Type: FieldTypeStringer, Any: val}
+}
+
+// Any constructs a Field holding an arbitrary value, for types that don't
+// have a dedicated constructor.
+func Any(key string, val interface{}) Field {
+	return Field{Key: key, Type: FieldTypeAny, Any: val}
+}
@@ -0,0 +1,187 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Formatter renders an Entry into the bytes that get written to a
+// DefaultLogger's sink. Implementations must not retain e.Fields.
+type Formatter interface {
+	Format(e *Entry) ([]byte, error)
+}
+
+// color escape codes used by TextFormatter when colors are enabled.
+const (
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorYellow = "\x1b[33m"
+	colorCyan   = "\x1b[36m"
+	colorGray   = "\x1b[90m"
+)
+
+func levelColor(l Level) string {
+	switch l {
+	case LevelFatal, LevelCritical, LevelError:
+		return colorRed
+	case LevelWarning:
+		return colorYellow
+	case LevelDebug, LevelTrace:
+		return colorGray
+	default:
+		return colorCyan
+	}
+}
+
+// TextFormatter renders an Entry as a human-readable line:
+//
+//	2021-01-02T15:04:05Z [ERROR] [actor:serialiser event:failed to open file] could not read config path=/etc/app.conf
+type TextFormatter struct {
+	// TimeFormat overrides the timestamp layout (defaults to time.RFC3339).
+	TimeFormat string
+	// ForceColors enables ANSI colors even when the sink is not a terminal.
+	ForceColors bool
+	// DisableColors disables ANSI colors even when ForceColors would otherwise apply.
+	DisableColors bool
+}
+
+// Format implements Formatter.
+func (f *TextFormatter) Format(e *Entry) ([]byte, error) {
+	timeFormat := f.TimeFormat
+	if timeFormat == "" {
+		timeFormat = defaultTimeFormat
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(e.Time.Format(timeFormat))
+	buf.WriteByte(' ')
+
+	if f.ForceColors && !f.DisableColors {
+		buf.WriteString(levelColor(e.Level))
+	}
+	fmt.Fprintf(&buf, "[%s]", strings.ToUpper(e.Level.String()))
+	if f.ForceColors && !f.DisableColors {
+		buf.WriteString(colorReset)
+	}
+
+	if e.Actor != "" || e.Event != "" {
+		buf.WriteString(" [")
+		if e.Actor != "" {
+			fmt.Fprintf(&buf, "actor:%s", e.Actor)
+		}
+		if e.Event != "" {
+			if e.Actor != "" {
+				buf.WriteByte(' ')
+			}
+			fmt.Fprintf(&buf, "event:%s", e.Event)
+		}
+		buf.WriteByte(']')
+	}
+
+	buf.WriteByte(' ')
+	buf.WriteString(e.Message)
+
+	for _, key := range sortedKeys(e.Fields) {
+		fmt.Fprintf(&buf, " %s=%v", key, e.Fields[key])
+	}
+
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// JSONFormatter renders an Entry as a single line of JSON.
+type JSONFormatter struct {
+	// TimeFormat overrides the timestamp layout (defaults to time.RFC3339).
+	TimeFormat string
+}
+
+// Format implements Formatter.
+func (f *JSONFormatter) Format(e *Entry) ([]byte, error) {
+	timeFormat := f.TimeFormat
+	if timeFormat == "" {
+		timeFormat = defaultTimeFormat
+	}
+
+	data := make(map[string]interface{}, len(e.Fields)+4)
+	for k, v := range e.Fields {
+		data[k] = v
+	}
+	data["time"] = e.Time.Format(timeFormat)
+	data["level"] = e.Level.String()
+	data["msg"] = e.Message
+	if e.Actor != "" {
+		data["actor"] = e.Actor
+	}
+	if e.Event != "" {
+		data["event"] = e.Event
+	}
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, '\n'), nil
+}
+
+// LogfmtFormatter renders an Entry as logfmt (key=value pairs).
+type LogfmtFormatter struct {
+	// TimeFormat overrides the timestamp layout (defaults to time.RFC3339).
+	TimeFormat string
+}
+
+// Format implements Formatter.
+func (f *LogfmtFormatter) Format(e *Entry) ([]byte, error) {
+	timeFormat := f.TimeFormat
+	if timeFormat == "" {
+		timeFormat = defaultTimeFormat
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "time=%s level=%s", e.Time.Format(timeFormat), e.Level.String())
+	fmt.Fprintf(&buf, " msg=%s", logfmtQuote(e.Message))
+	if e.Actor != "" {
+		fmt.Fprintf(&buf, " actor=%s", logfmtQuote(e.Actor))
+	}
+	if e.Event != "" {
+		fmt.Fprintf(&buf, " event=%s", logfmtQuote(e.Event))
+	}
+	for _, key := range sortedKeys(e.Fields) {
+		fmt.Fprintf(&buf, " %s=%s", key, logfmtQuote(fmt.Sprintf("%v", e.Fields[key])))
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// logfmtQuote quotes s if it contains characters that would break logfmt's
+// unquoted token grammar.
+func logfmtQuote(s string) string {
+	if s == "" {
+		return `""`
+	}
+	needsQuote := false
+	for _, r := range s {
+		if r <= ' ' || r == '"' || r == '=' {
+			needsQuote = true
+			break
+		}
+	}
+	if !needsQuote {
+		return s
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
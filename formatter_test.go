@@ -0,0 +1,120 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var formatterTestTime = time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func TestTextFormatterFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		e    *Entry
+		want string
+	}{
+		{
+			name: "plain message",
+			e:    &Entry{Time: formatterTestTime, Level: LevelInfo, Message: "hello"},
+			want: "2021-01-02T15:04:05Z [INFO] hello\n",
+		},
+		{
+			name: "actor and event with a field",
+			e: &Entry{
+				Time:    formatterTestTime,
+				Level:   LevelError,
+				Message: "could not read config",
+				Actor:   "serialiser",
+				Event:   "failed to open file",
+				Fields:  map[string]interface{}{"path": "/etc/app.conf"},
+			},
+			want: "2021-01-02T15:04:05Z [ERROR] [actor:serialiser event:failed to open file] could not read config path=/etc/app.conf\n",
+		},
+		{
+			name: "actor without event",
+			e: &Entry{
+				Time: formatterTestTime, Level: LevelWarning, Message: "msg", Actor: "worker",
+			},
+			want: "2021-01-02T15:04:05Z [WARNING] [actor:worker] msg\n",
+		},
+		{
+			name: "fields are rendered in sorted key order",
+			e: &Entry{
+				Time: formatterTestTime, Level: LevelDebug, Message: "msg",
+				Fields: map[string]interface{}{"b": 2, "a": 1},
+			},
+			want: "2021-01-02T15:04:05Z [DEBUG] msg a=1 b=2\n",
+		},
+	}
+
+	f := &TextFormatter{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.Format(tt.e)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONFormatterFormat(t *testing.T) {
+	f := &JSONFormatter{}
+	e := &Entry{
+		Time:    formatterTestTime,
+		Level:   LevelError,
+		Message: "boom",
+		Actor:   "serialiser",
+		Event:   "failed to open file",
+		Fields:  map[string]interface{}{"path": "/etc/app.conf"},
+	}
+	out, err := f.Format(e)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(%s): %v", out, err)
+	}
+
+	want := map[string]interface{}{
+		"time":  formatterTestTime.Format(time.RFC3339),
+		"level": "error",
+		"msg":   "boom",
+		"actor": "serialiser",
+		"event": "failed to open file",
+		"path":  "/etc/app.conf",
+	}
+	for k, v := range want {
+		if decoded[k] != v {
+			t.Errorf("decoded[%q] = %v, want %v", k, decoded[k], v)
+		}
+	}
+}
+
+func TestLogfmtFormatterFormat(t *testing.T) {
+	f := &LogfmtFormatter{}
+	e := &Entry{
+		Time:    formatterTestTime,
+		Level:   LevelWarning,
+		Message: "disk is filling up",
+		Actor:   "disker",
+		Fields:  map[string]interface{}{"free_bytes": 128},
+	}
+	out, err := f.Format(e)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	want := `time=2021-01-02T15:04:05Z level=warning msg="disk is filling up" actor=disker free_bytes=128` + "\n"
+	if string(out) != want {
+		t.Errorf("Format() = %q, want %q", out, want)
+	}
+}
@@ -3,6 +3,8 @@
 
 package log
 
+import "context"
+
 // Logger is a simple logging interface to pass to library's.
 type Logger interface {
 	Tracef(format string, args ...interface{})
@@ -24,18 +26,48 @@ type Logger interface {
 // with extended features like level.
 type ExtendedLogger interface {
 	Logger
+	DeferredLogger
 
 	// Level returns current logging level (if supported)
 	Level() Level
 
-	// WithLevel returns a logger with logger level set to the passed argument (if supported)
-	WithLevel(Level) Logger
+	// IsEnabled returns whether the passed level would actually be logged (if supported)
+	IsEnabled(Level) bool
+
+	// WithLevel returns a logger with logger level set to the passed argument (if supported).
+	// It returns ExtendedLogger, not Logger, so the structured/deferred API survives scoping.
+	WithLevel(Level) ExtendedLogger
 
 	// 	Fields return current fields logger has set (if supported)
 	Fields() map[string]interface{}
 
-	// WithFields returns a logger with added fields (used for structured logging, if supported)
-	WithFields(fields map[string]interface{}) Logger
+	// WithFields returns a logger with added fields (used for structured logging, if supported).
+	// It returns ExtendedLogger, not Logger, so the structured/deferred API survives scoping.
+	WithFields(fields map[string]interface{}) ExtendedLogger
+
+	// WithContext returns a logger scoped to ctx (if supported). When ctx
+	// carries an OpenTelemetry span context and this module was built with
+	// the "otel" build tag, the returned logger attaches trace_id, span_id
+	// and trace_flags fields to every entry. It returns ExtendedLogger, not
+	// Logger, so the structured/deferred API survives scoping.
+	WithContext(ctx context.Context) ExtendedLogger
+
+	// WithActor returns a logger that annotates every entry with the given actor (if supported).
+	// It returns ExtendedLogger so the actor/event pair can still be combined with *KV calls,
+	// e.g. log.FromContext(ctx).WithActor("serialiser").WithEvent("failed to open file").ErrorKV(...).
+	WithActor(name string) ExtendedLogger
+
+	// WithEvent returns a logger that annotates every entry with the given event (if supported).
+	// It returns ExtendedLogger for the same reason WithActor does.
+	WithEvent(event string) ExtendedLogger
+
+	// TraceKV, DebugKV, ... log msg with typed structured fields attached (if supported)
+	TraceKV(msg string, fields ...Field)
+	DebugKV(msg string, fields ...Field)
+	InfoKV(msg string, fields ...Field)
+	WarnKV(msg string, fields ...Field)
+	ErrorKV(msg string, fields ...Field)
+	CriticalKV(msg string, fields ...Field)
 
 	// Flush signal logger to empty cache (if supported)
 	Flush() error
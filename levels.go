@@ -4,6 +4,7 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -38,6 +39,21 @@ const (
 	LevelTrace
 )
 
+// AllLevels returns every defined Level other than LevelUndefined, ordered
+// from least to most verbose. It backs both ParseLogLevel's error message
+// and config-file parsers that need to enumerate the accepted values.
+func AllLevels() []Level {
+	return []Level{
+		LevelFatal,
+		LevelCritical,
+		LevelError,
+		LevelWarning,
+		LevelInfo,
+		LevelDebug,
+		LevelTrace,
+	}
+}
+
 // String just implements fmt.Stringer, flag.Value and pflag.Value.
 func (logLevel Level) String() string {
 	switch logLevel {
@@ -61,12 +77,71 @@ func (logLevel Level) String() string {
 	return "unknown"
 }
 
+// Enabled reports whether a message logged at other would be emitted by a
+// filter configured at logLevel. Level values increase with verbosity
+// (LevelFatal is the least verbose, LevelTrace the most), so other is
+// enabled whenever it is no more verbose than logLevel. This is the single
+// canonical severity comparison; filters should use it instead of
+// comparing Level values directly.
+func (logLevel Level) Enabled(other Level) bool {
+	return other <= logLevel
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (logLevel Level) MarshalText() ([]byte, error) {
+	return []byte(logLevel.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (logLevel *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLogLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*logLevel = level
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (logLevel Level) MarshalJSON() ([]byte, error) {
+	return json.Marshal(logLevel.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (logLevel *Level) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	level, err := ParseLogLevel(s)
+	if err != nil {
+		return err
+	}
+	*logLevel = level
+	return nil
+}
+
+// Set implements flag.Value and pflag.Value.
+func (logLevel *Level) Set(s string) error {
+	level, err := ParseLogLevel(s)
+	if err != nil {
+		return err
+	}
+	*logLevel = level
+	return nil
+}
+
+// Type implements pflag.Value.
+func (logLevel *Level) Type() string {
+	return "level"
+}
+
 // ParseLogLevel parses incoming string into a Level and returns
 // LevelUndefined with an error if an unknown logging level was passed.
 func ParseLogLevel(in string) (Level, error) {
 	switch strings.ToLower(in) {
 	case "t", "trace":
-		return LevelDebug, nil
+		return LevelTrace, nil
 	case "d", "debug":
 		return LevelDebug, nil
 	case "i", "info":
@@ -80,8 +155,8 @@ func ParseLogLevel(in string) (Level, error) {
 	case "f", "fatal":
 		return LevelFatal, nil
 	}
-	var allowedValues []string
-	for logLevel := LevelFatal; logLevel <= LevelDebug; logLevel++ {
+	allowedValues := make([]string, 0, len(AllLevels()))
+	for _, logLevel := range AllLevels() {
 		allowedValues = append(allowedValues, logLevel.String())
 	}
 	return LevelUndefined, fmt.Errorf("unknown logging level '%s', known values are: %s",
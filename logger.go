@@ -0,0 +1,482 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultTimeFormat = time.RFC3339
+
+// DefaultLogger is the in-tree ExtendedLogger implementation. It is safe
+// for concurrent use: mutable fields are guarded by a RWMutex and
+// WithFields/WithActor/WithEvent/WithLevel return a shallow copy carrying
+// a defensively-copied Fields map, so two loggers derived from the same
+// parent never share map state. The writer is guarded separately by
+// writeMu, a mutex shared across every clone of the same root logger, so
+// concurrent entries from clones that share a sink are never interleaved
+// or racy.
+type DefaultLogger struct {
+	mu sync.RWMutex
+
+	level      Level
+	fields     map[string]interface{}
+	actor      string
+	event      string
+	writer     io.Writer
+	formatter  Formatter
+	timeFormat string
+	colorMode  colorMode
+
+	writeMu *sync.Mutex
+}
+
+// colorMode records a pending WithForceColors/WithEnvironmentOverrideColors
+// request so it can be applied after every Option has run and the final
+// formatter is known, mirroring applyTimeFormat.
+type colorMode int
+
+const (
+	colorModeDefault colorMode = iota
+	colorModeForce
+	colorModeEnvOverride
+)
+
+// Option configures a DefaultLogger created with New.
+type Option func(*DefaultLogger)
+
+// WithWriter sets the sink entries are written to. Defaults to os.Stderr.
+func WithWriter(w io.Writer) Option {
+	return func(l *DefaultLogger) { l.writer = w }
+}
+
+// WithFormatter selects the Formatter used to render entries. Defaults to
+// a *TextFormatter, or whatever LOG_FORMAT requests if set.
+func WithFormatter(f Formatter) Option {
+	return func(l *DefaultLogger) { l.formatter = f }
+}
+
+// WithInitialLevel sets the level new entries are filtered against.
+// Defaults to LevelInfo.
+func WithInitialLevel(level Level) Option {
+	return func(l *DefaultLogger) { l.level = level }
+}
+
+// WithTimeFormat overrides the timestamp layout used by the formatter.
+// Defaults to time.RFC3339.
+func WithTimeFormat(layout string) Option {
+	return func(l *DefaultLogger) { l.timeFormat = layout }
+}
+
+// WithForceColors forces the built-in TextFormatter to emit ANSI colors
+// regardless of whether the sink is a terminal. It has no effect unless
+// the final formatter is a *TextFormatter (the default, or one supplied
+// via WithFormatter). The formatter may be
+// set by a later Option (e.g. WithFormatter), so the actual toggle is
+// deferred until New has applied every Option.
+func WithForceColors() Option {
+	return func(l *DefaultLogger) { l.colorMode = colorModeForce }
+}
+
+// WithEnvironmentOverrideColors enables ANSI colors on the built-in
+// TextFormatter unless the NO_COLOR environment variable is set,
+// following the convention described at https://no-color.org. Like
+// WithForceColors, the toggle is deferred until after every Option has
+// run so option order relative to WithFormatter doesn't matter.
+func WithEnvironmentOverrideColors() Option {
+	return func(l *DefaultLogger) { l.colorMode = colorModeEnvOverride }
+}
+
+// New constructs a DefaultLogger. Unless WithFormatter is passed, the
+// formatter is picked from the LOG_FORMAT environment variable
+// ("json", "text" or "logfmt"), defaulting to text.
+func New(opts ...Option) *DefaultLogger {
+	l := &DefaultLogger{
+		level:      LevelInfo,
+		fields:     map[string]interface{}{},
+		writer:     os.Stderr,
+		formatter:  formatterFromEnv(),
+		timeFormat: defaultTimeFormat,
+		writeMu:    &sync.Mutex{},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.applyTimeFormat()
+	l.applyColors()
+	return l
+}
+
+// applyTimeFormat propagates an explicit WithTimeFormat option to the
+// built-in formatters, which otherwise fall back to defaultTimeFormat.
+func (l *DefaultLogger) applyTimeFormat() {
+	if l.timeFormat == defaultTimeFormat {
+		return
+	}
+	switch f := l.formatter.(type) {
+	case *TextFormatter:
+		f.TimeFormat = l.timeFormat
+	case *JSONFormatter:
+		f.TimeFormat = l.timeFormat
+	case *LogfmtFormatter:
+		f.TimeFormat = l.timeFormat
+	}
+}
+
+// applyColors propagates a WithForceColors/WithEnvironmentOverrideColors
+// option to the final formatter, once it's known. It has no effect on
+// formatters other than *TextFormatter.
+func (l *DefaultLogger) applyColors() {
+	if l.colorMode == colorModeDefault {
+		return
+	}
+	tf, ok := l.formatter.(*TextFormatter)
+	if !ok {
+		return
+	}
+	switch l.colorMode {
+	case colorModeForce:
+		tf.ForceColors = true
+	case colorModeEnvOverride:
+		if os.Getenv("NO_COLOR") == "" {
+			tf.ForceColors = true
+		}
+	}
+}
+
+func formatterFromEnv() Formatter {
+	switch os.Getenv("LOG_FORMAT") {
+	case "json":
+		return &JSONFormatter{}
+	case "logfmt":
+		return &LogfmtFormatter{}
+	default:
+		return &TextFormatter{}
+	}
+}
+
+// Level returns the current logging level.
+func (l *DefaultLogger) Level() Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.level
+}
+
+// IsEnabled returns whether level would actually be emitted.
+func (l *DefaultLogger) IsEnabled(level Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.level.Enabled(level)
+}
+
+// WithLevel returns a logger with its level set to level.
+func (l *DefaultLogger) WithLevel(level Level) ExtendedLogger {
+	clone := l.clone()
+	clone.level = level
+	return clone
+}
+
+// Fields returns the fields currently attached to the logger.
+func (l *DefaultLogger) Fields() map[string]interface{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	fields := make(map[string]interface{}, len(l.fields))
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	return fields
+}
+
+// WithFields returns a logger with fields merged into its existing fields.
+func (l *DefaultLogger) WithFields(fields map[string]interface{}) ExtendedLogger {
+	clone := l.clone()
+	for k, v := range fields {
+		clone.fields[k] = v
+	}
+	return clone
+}
+
+// WithContext returns a logger scoped to ctx. With the "otel" build tag
+// and a valid OpenTelemetry span context on ctx, the returned logger
+// attaches trace_id/span_id/trace_flags fields to every entry.
+func (l *DefaultLogger) WithContext(ctx context.Context) ExtendedLogger {
+	return injectTraceFields(ctx, l)
+}
+
+// WithActor returns a logger that annotates every entry with actor.
+func (l *DefaultLogger) WithActor(actor string) ExtendedLogger {
+	clone := l.clone()
+	clone.actor = actor
+	return clone
+}
+
+// WithEvent returns a logger that annotates every entry with event.
+func (l *DefaultLogger) WithEvent(event string) ExtendedLogger {
+	clone := l.clone()
+	clone.event = event
+	return clone
+}
+
+// clone copies l, defensively copying the Fields map so the original and
+// the clone never share mutable state.
+func (l *DefaultLogger) clone() *DefaultLogger {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	fields := make(map[string]interface{}, len(l.fields))
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	return &DefaultLogger{
+		level:      l.level,
+		fields:     fields,
+		actor:      l.actor,
+		event:      l.event,
+		writer:     l.writer,
+		formatter:  l.formatter,
+		timeFormat: l.timeFormat,
+		writeMu:    l.writeMu,
+	}
+}
+
+// Flush is a no-op unless the configured writer implements it.
+func (l *DefaultLogger) Flush() error {
+	l.mu.RLock()
+	writer := l.writer
+	l.mu.RUnlock()
+
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	if f, ok := writer.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close is a no-op unless the configured writer implements io.Closer.
+func (l *DefaultLogger) Close() error {
+	l.mu.RLock()
+	writer := l.writer
+	l.mu.RUnlock()
+
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	if c, ok := writer.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (l *DefaultLogger) log(level Level, msg string, fields map[string]interface{}) {
+	l.mu.RLock()
+	if !l.level.Enabled(level) {
+		l.mu.RUnlock()
+		return
+	}
+	entry := &Entry{
+		Time:    time.Now(),
+		Level:   level,
+		Message: msg,
+		Actor:   l.actor,
+		Event:   l.event,
+		Fields:  fields,
+	}
+	formatter := l.formatter
+	writer := l.writer
+	l.mu.RUnlock()
+
+	if entry.Fields == nil {
+		entry.Fields = l.Fields()
+	}
+
+	out, err := formatter.Format(entry)
+	if err != nil {
+		return
+	}
+
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	_, _ = writer.Write(out)
+}
+
+func (l *DefaultLogger) logf(level Level, format string, args ...interface{}) {
+	if !l.IsEnabled(level) {
+		return
+	}
+	l.log(level, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *DefaultLogger) logKV(level Level, msg string, fields ...Field) {
+	if !l.IsEnabled(level) {
+		return
+	}
+	merged := l.Fields()
+	for _, f := range fields {
+		merged[f.Key] = fieldValue(f)
+	}
+	l.log(level, msg, merged)
+}
+
+func (l *DefaultLogger) logDeferred(level Level, fn func() string) {
+	if !l.IsEnabled(level) {
+		return
+	}
+	l.log(level, fn(), nil)
+}
+
+func fieldValue(f Field) interface{} {
+	switch f.Type {
+	case FieldTypeBool:
+		return f.Int != 0
+	case FieldTypeInt64:
+		return f.Int
+	case FieldTypeUint64:
+		return uint64(f.Int)
+	case FieldTypeFloat64:
+		return f.Float
+	case FieldTypeString:
+		return f.Str
+	case FieldTypeDuration:
+		return time.Duration(f.Int)
+	case FieldTypeError:
+		if err, ok := f.Any.(error); ok && err != nil {
+			return err.Error()
+		}
+		return nil
+	case FieldTypeStringer:
+		if s, ok := f.Any.(fmt.Stringer); ok && s != nil {
+			return s.String()
+		}
+		return nil
+	default:
+		return f.Any
+	}
+}
+
+// Tracef implements Logger.
+func (l *DefaultLogger) Tracef(format string, args ...interface{}) {
+	l.logf(LevelTrace, format, args...)
+}
+
+// Debugf implements Logger.
+func (l *DefaultLogger) Debugf(format string, args ...interface{}) {
+	l.logf(LevelDebug, format, args...)
+}
+
+// Infof implements Logger.
+func (l *DefaultLogger) Infof(format string, args ...interface{}) {
+	l.logf(LevelInfo, format, args...)
+}
+
+// Warnf implements Logger.
+func (l *DefaultLogger) Warnf(format string, args ...interface{}) {
+	l.logf(LevelWarning, format, args...)
+}
+
+// Errorf implements Logger.
+func (l *DefaultLogger) Errorf(format string, args ...interface{}) {
+	l.logf(LevelError, format, args...)
+}
+
+// Criticalf implements Logger.
+func (l *DefaultLogger) Criticalf(format string, args ...interface{}) {
+	l.logf(LevelCritical, format, args...)
+}
+
+// Trace implements Logger.
+func (l *DefaultLogger) Trace(obj interface{}) {
+	l.logf(LevelTrace, "%v", obj)
+}
+
+// Debug implements Logger.
+func (l *DefaultLogger) Debug(obj interface{}) {
+	l.logf(LevelDebug, "%v", obj)
+}
+
+// Info implements Logger.
+func (l *DefaultLogger) Info(obj interface{}) {
+	l.logf(LevelInfo, "%v", obj)
+}
+
+// Warn implements Logger.
+func (l *DefaultLogger) Warn(obj interface{}) {
+	l.logf(LevelWarning, "%v", obj)
+}
+
+// Error implements Logger.
+func (l *DefaultLogger) Error(obj interface{}) {
+	l.logf(LevelError, "%v", obj)
+}
+
+// Critical implements Logger.
+func (l *DefaultLogger) Critical(obj interface{}) {
+	l.logf(LevelCritical, "%v", obj)
+}
+
+// TraceKV implements ExtendedLogger.
+func (l *DefaultLogger) TraceKV(msg string, fields ...Field) {
+	l.logKV(LevelTrace, msg, fields...)
+}
+
+// DebugKV implements ExtendedLogger.
+func (l *DefaultLogger) DebugKV(msg string, fields ...Field) {
+	l.logKV(LevelDebug, msg, fields...)
+}
+
+// InfoKV implements ExtendedLogger.
+func (l *DefaultLogger) InfoKV(msg string, fields ...Field) {
+	l.logKV(LevelInfo, msg, fields...)
+}
+
+// WarnKV implements ExtendedLogger.
+func (l *DefaultLogger) WarnKV(msg string, fields ...Field) {
+	l.logKV(LevelWarning, msg, fields...)
+}
+
+// ErrorKV implements ExtendedLogger.
+func (l *DefaultLogger) ErrorKV(msg string, fields ...Field) {
+	l.logKV(LevelError, msg, fields...)
+}
+
+// CriticalKV implements ExtendedLogger.
+func (l *DefaultLogger) CriticalKV(msg string, fields ...Field) {
+	l.logKV(LevelCritical, msg, fields...)
+}
+
+// TraceDeferred implements DeferredLogger.
+func (l *DefaultLogger) TraceDeferred(fn func() string) {
+	l.logDeferred(LevelTrace, fn)
+}
+
+// DebugDeferred implements DeferredLogger.
+func (l *DefaultLogger) DebugDeferred(fn func() string) {
+	l.logDeferred(LevelDebug, fn)
+}
+
+// InfoDeferred implements DeferredLogger.
+func (l *DefaultLogger) InfoDeferred(fn func() string) {
+	l.logDeferred(LevelInfo, fn)
+}
+
+// WarnDeferred implements DeferredLogger.
+func (l *DefaultLogger) WarnDeferred(fn func() string) {
+	l.logDeferred(LevelWarning, fn)
+}
+
+// ErrorDeferred implements DeferredLogger.
+func (l *DefaultLogger) ErrorDeferred(fn func() string) {
+	l.logDeferred(LevelError, fn)
+}
+
+// CriticalDeferred implements DeferredLogger.
+func (l *DefaultLogger) CriticalDeferred(fn func() string) {
+	l.logDeferred(LevelCritical, fn)
+}
+
+var _ ExtendedLogger = (*DefaultLogger)(nil)
@@ -0,0 +1,185 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestLogger(level Level) (*DefaultLogger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	l := New(WithWriter(buf), WithInitialLevel(level), WithFormatter(&TextFormatter{}))
+	return l, buf
+}
+
+func TestDefaultLoggerLevelFiltering(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo)
+
+	l.Debugf("should not appear")
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing logged below the configured level, got %q", buf.String())
+	}
+
+	l.Infof("should appear")
+	if !strings.Contains(buf.String(), "should appear") {
+		t.Fatalf("expected message at the configured level to be logged, got %q", buf.String())
+	}
+}
+
+func TestDefaultLoggerIsEnabled(t *testing.T) {
+	l, _ := newTestLogger(LevelWarning)
+
+	if l.IsEnabled(LevelInfo) {
+		t.Error("LevelInfo should not be enabled when configured at LevelWarning")
+	}
+	if !l.IsEnabled(LevelError) {
+		t.Error("LevelError should be enabled when configured at LevelWarning")
+	}
+	if !l.IsEnabled(LevelWarning) {
+		t.Error("LevelWarning should be enabled when configured at LevelWarning")
+	}
+}
+
+func TestDeferredNotInvokedWhenDisabled(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo)
+
+	called := false
+	l.DebugDeferred(func() string {
+		called = true
+		return "expensive"
+	})
+	if called {
+		t.Error("deferred closure was invoked even though LevelDebug is disabled")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing logged, got %q", buf.String())
+	}
+
+	called = false
+	l.InfoDeferred(func() string {
+		called = true
+		return "expensive"
+	})
+	if !called {
+		t.Error("deferred closure was not invoked even though LevelInfo is enabled")
+	}
+	if !strings.Contains(buf.String(), "expensive") {
+		t.Errorf("expected message to be logged, got %q", buf.String())
+	}
+}
+
+func TestDefaultLoggerKVFields(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo)
+
+	l.ErrorKV("boom", String("path", "/tmp/x"), Int64("attempt", 3))
+	out := buf.String()
+	if !strings.Contains(out, "path=/tmp/x") {
+		t.Errorf("expected path field in output, got %q", out)
+	}
+	if !strings.Contains(out, "attempt=3") {
+		t.Errorf("expected attempt field in output, got %q", out)
+	}
+}
+
+func TestDefaultLoggerWithActorAndEvent(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo)
+
+	scoped := l.WithActor("serialiser").WithEvent("failed to open file")
+	scoped.ErrorKV("could not read config", String("path", "/etc/app.conf"))
+
+	want := "[actor:serialiser event:failed to open file] could not read config path=/etc/app.conf"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("got %q, want substring %q", buf.String(), want)
+	}
+}
+
+func TestDefaultLoggerWithFieldsDoesNotMutateParent(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo)
+
+	child := l.WithFields(map[string]interface{}{"request_id": "abc"})
+	child.Infof("from child")
+	l.Infof("from parent")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "request_id=abc") {
+		t.Errorf("expected child entry to carry request_id, got %q", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("parent logger should not have gained request_id, got %q", lines[1])
+	}
+}
+
+func TestDefaultLoggerConcurrentWritesAreSafe(t *testing.T) {
+	l, buf := newTestLogger(LevelInfo)
+	child := l.WithActor("worker").(*DefaultLogger)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			l.Infof("from root")
+		}()
+		go func() {
+			defer wg.Done()
+			child.Infof("from child")
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("expected 100 complete log lines from concurrent writers, got %d", len(lines))
+	}
+}
+
+func TestWithForceColorsAppliesRegardlessOfOptionOrder(t *testing.T) {
+	before := New(WithForceColors(), WithFormatter(&TextFormatter{}))
+	after := New(WithFormatter(&TextFormatter{}), WithForceColors())
+
+	if !before.formatter.(*TextFormatter).ForceColors {
+		t.Error("WithForceColors before WithFormatter: expected ForceColors to be set")
+	}
+	if !after.formatter.(*TextFormatter).ForceColors {
+		t.Error("WithForceColors after WithFormatter: expected ForceColors to be set")
+	}
+}
+
+func TestWithEnvironmentOverrideColorsHonorsNoColor(t *testing.T) {
+	t.Run("NO_COLOR unset", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		os.Unsetenv("NO_COLOR")
+
+		l := New(WithEnvironmentOverrideColors(), WithFormatter(&TextFormatter{}))
+		if !l.formatter.(*TextFormatter).ForceColors {
+			t.Error("expected ForceColors to be set when NO_COLOR is unset")
+		}
+	})
+
+	t.Run("NO_COLOR set", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+
+		l := New(WithFormatter(&TextFormatter{}), WithEnvironmentOverrideColors())
+		if l.formatter.(*TextFormatter).ForceColors {
+			t.Error("expected ForceColors to remain unset when NO_COLOR is set")
+		}
+	})
+}
+
+func TestWithEnvironmentOverrideColorsNoEffectOnCustomFormatter(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+
+	l := New(WithEnvironmentOverrideColors(), WithFormatter(&JSONFormatter{}))
+	if _, ok := l.formatter.(*TextFormatter); ok {
+		t.Fatal("expected JSONFormatter to remain the configured formatter")
+	}
+}
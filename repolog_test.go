@@ -0,0 +1,73 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package log
+
+import "testing"
+
+// forgetRepoLogger removes repo's RepoLogger from the package-level
+// registry so a test that mutates a package's level doesn't leak state
+// into a later run of the same test (e.g. under `go test -count=2`).
+func forgetRepoLogger(repo string) {
+	reposMu.Lock()
+	defer reposMu.Unlock()
+	delete(repos, repo)
+}
+
+func TestNewPackageLoggerTagsEntriesWithRepoAndPkg(t *testing.T) {
+	l := NewPackageLogger("test-repo-tag", "mypkg")
+
+	fields := l.Fields()
+	if fields["repo"] != "test-repo-tag" {
+		t.Errorf("Fields()[\"repo\"] = %v, want %q", fields["repo"], "test-repo-tag")
+	}
+	if fields["pkg"] != "mypkg" {
+		t.Errorf("Fields()[\"pkg\"] = %v, want %q", fields["pkg"], "mypkg")
+	}
+}
+
+func TestRepoLoggerSetLogLevelUpdatesExistingPackage(t *testing.T) {
+	t.Cleanup(func() { forgetRepoLogger("test-repo-existing") })
+
+	l := NewPackageLogger("test-repo-existing", "existing")
+	if l.Level() != LevelInfo {
+		t.Fatalf("expected default level LevelInfo, got %v", l.Level())
+	}
+
+	MustRepoLogger("test-repo-existing").SetLogLevel(map[string]Level{"existing": LevelWarning})
+
+	if l.Level() != LevelWarning {
+		t.Errorf("Level() = %v, want %v", l.Level(), LevelWarning)
+	}
+}
+
+func TestRepoLoggerSetLogLevelPersistsForFuturePackages(t *testing.T) {
+	rl := MustRepoLogger("test-repo-future")
+
+	cfg, err := rl.ParseLogLevelConfig("not-yet-created=debug")
+	if err != nil {
+		t.Fatalf("ParseLogLevelConfig: %v", err)
+	}
+	rl.SetLogLevel(cfg)
+
+	l := NewPackageLogger("test-repo-future", "not-yet-created")
+	if l.Level() != LevelDebug {
+		t.Errorf("Level() = %v, want %v (override should apply to packages created after SetLogLevel)", l.Level(), LevelDebug)
+	}
+}
+
+func TestLogLevelConfigFlagValueRoundTrip(t *testing.T) {
+	var cfg LogLevelConfig
+	if err := cfg.Set("server=debug,server/router=warn"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg["server"] != LevelDebug {
+		t.Errorf("cfg[\"server\"] = %v, want %v", cfg["server"], LevelDebug)
+	}
+	if cfg["server/router"] != LevelWarning {
+		t.Errorf("cfg[\"server/router\"] = %v, want %v", cfg["server/router"], LevelWarning)
+	}
+	if err := cfg.Set("bogus-pkg-no-equals"); err == nil {
+		t.Error("Set with a malformed entry should return an error")
+	}
+}
@@ -0,0 +1,15 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build !otel
+
+package log
+
+import "context"
+
+// injectTraceFields is the no-op build of the OpenTelemetry correlation
+// glue, used whenever this module is built without the "otel" build tag
+// so that go.opentelemetry.io/otel stays an optional dependency.
+func injectTraceFields(_ context.Context, l ExtendedLogger) ExtendedLogger {
+	return l
+}
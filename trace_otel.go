@@ -0,0 +1,28 @@
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build otel
+
+package log
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// injectTraceFields attaches trace_id, span_id and trace_flags fields to l
+// when ctx carries a valid OpenTelemetry span context. It is only built
+// when the "otel" build tag is set, keeping go.opentelemetry.io/otel an
+// optional dependency of this module.
+func injectTraceFields(ctx context.Context, l ExtendedLogger) ExtendedLogger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+	return l.WithFields(map[string]interface{}{
+		"trace_id":    sc.TraceID().String(),
+		"span_id":     sc.SpanID().String(),
+		"trace_flags": sc.TraceFlags().String(),
+	})
+}